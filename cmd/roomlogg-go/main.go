@@ -0,0 +1,147 @@
+// Command roomlogg-go talks to a roomlogg USB base station.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/SimonSpi/roomlogg-go/sensor"
+	"github.com/SimonSpi/roomlogg-go/sensor/exporter"
+	"github.com/SimonSpi/roomlogg-go/sensor/remote"
+	"github.com/karalabe/hid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func deviceInfos(devices []sensor.Device) [][]hid.DeviceInfo {
+	infos := make([][]hid.DeviceInfo, len(devices))
+	for i, device := range devices {
+		infos[i] = device.Infos
+	}
+	return infos
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	switch os.Args[1] {
+	case "exporter":
+		runExporter(os.Args[2:])
+	case "sync":
+		runSync(os.Args[2:])
+	case "calibrate":
+		runCalibrate(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: roomlogg-go <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  exporter   serve sensor readings as Prometheus metrics")
+	fmt.Fprintln(os.Stderr, "  sync       force-flush the remote spool")
+	fmt.Fprintln(os.Stderr, "  calibrate  record a per-channel temperature offset")
+}
+
+func runExporter(args []string) {
+	fs := flag.NewFlagSet("exporter", flag.ExitOnError)
+	addr := fs.String("addr", ":9101", "address to listen on for /metrics")
+	calibrationPath := fs.String("calibration", "", "path to a calibration config to apply to readings")
+	fs.Parse(args)
+
+	if *calibrationPath != "" {
+		calib, err := sensor.LoadCalibration(*calibrationPath)
+		if err != nil {
+			log.Fatalf("exporter: %v", err)
+		}
+		sensor.SetCalibration(calib)
+	}
+
+	devices := sensor.Discover()
+	if len(devices) == 0 {
+		log.Fatal("exporter: no roomlogg devices found")
+	}
+
+	reg := prometheus.NewRegistry()
+	exp := exporter.New(deviceInfos(devices), reg, exporter.Config{})
+
+	http.Handle("/metrics", exp.Handler())
+	log.Printf("exporter: listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+func runSync(args []string) {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	endpoint := fs.String("endpoint", "", "remote collector URL to POST readings to")
+	spoolPath := fs.String("spool", "roomlogg-spool.jsonl", "path to the on-disk spool file")
+	fs.Parse(args)
+
+	if *endpoint == "" {
+		log.Fatal("sync: -endpoint is required")
+	}
+
+	client, err := remote.NewClient(remote.Config{
+		Endpoint:  *endpoint,
+		SpoolPath: *spoolPath,
+	})
+	if err != nil {
+		log.Fatalf("sync: %v", err)
+	}
+
+	if err := client.Flush(context.Background()); err != nil {
+		log.Fatalf("sync: flush failed: %v", err)
+	}
+	log.Println("sync: spool flushed")
+}
+
+func runCalibrate(args []string) {
+	fs := flag.NewFlagSet("calibrate", flag.ExitOnError)
+	configPath := fs.String("config", "roomlogg-calibration.json", "path to the calibration config")
+	channel := fs.Int("channel", -1, "channel to calibrate")
+	reference := fs.Float64("reference", 0, "known-correct reference temperature in °C")
+	fs.Parse(args)
+
+	if *channel < 0 {
+		log.Fatal("calibrate: -channel is required")
+	}
+
+	devices := sensor.Discover()
+	if len(devices) == 0 {
+		log.Fatal("calibrate: no roomlogg devices found")
+	}
+
+	sensors, err := devices[0].Query()
+	if err != nil {
+		log.Fatalf("calibrate: querying device: %v", err)
+	}
+
+	var current *sensor.Sensor
+	for _, s := range sensors {
+		if s.Channel == *channel {
+			current = s
+			break
+		}
+	}
+	if current == nil || current.Absent {
+		log.Fatalf("calibrate: channel %d has no sensor attached", *channel)
+	}
+
+	calib, err := sensor.LoadCalibration(*configPath)
+	if err != nil {
+		log.Fatalf("calibrate: %v", err)
+	}
+
+	calib.SetTemperatureOffset(*channel, current.Temperature, float32(*reference))
+	if err := calib.Save(*configPath); err != nil {
+		log.Fatalf("calibrate: %v", err)
+	}
+	log.Printf("calibrate: channel %d offset saved to %s", *channel, *configPath)
+}
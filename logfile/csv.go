@@ -0,0 +1,121 @@
+package logfile
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+)
+
+var csvHeader = []string{"timestamp", "channel", "temperature", "humidity", "absent"}
+
+// CSVWriter appends Records as comma-separated rows, writing a header once
+// when the file is empty.
+type CSVWriter struct {
+	file *os.File
+	w    *csv.Writer
+}
+
+// NewCSVWriter opens or creates the CSV log at path.
+func NewCSVWriter(path string) (*CSVWriter, error) {
+	info, err := os.Stat(path)
+	empty := err != nil || info.Size() == 0
+
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := csv.NewWriter(file)
+	if empty {
+		if err := w.Write(csvHeader); err != nil {
+			file.Close()
+			return nil, err
+		}
+		w.Flush()
+	}
+
+	return &CSVWriter{file: file, w: w}, nil
+}
+
+// Write implements Writer.
+func (c *CSVWriter) Write(record Record) error {
+	row := []string{
+		record.Timestamp.Format(time.RFC3339),
+		strconv.Itoa(record.Channel),
+		strconv.FormatFloat(float64(record.Temperature), 'f', -1, 32),
+		strconv.Itoa(int(record.Humidity)),
+		strconv.FormatBool(record.Absent),
+	}
+	if err := c.w.Write(row); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Close implements Writer.
+func (c *CSVWriter) Close() error {
+	c.w.Flush()
+	return c.file.Close()
+}
+
+// CSVReader replays Records from a CSV log written by CSVWriter.
+type CSVReader struct {
+	file *os.File
+}
+
+// NewCSVReader opens the CSV log at path for replay.
+func NewCSVReader(path string) (*CSVReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &CSVReader{file: file}, nil
+}
+
+// ReadAll implements Reader.
+func (c *CSVReader) ReadAll() ([]Record, error) {
+	defer c.file.Close()
+
+	r := csv.NewReader(c.file)
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	records := make([]Record, 0, len(rows)-1)
+	for _, row := range rows[1:] { // skip header
+		ts, err := time.Parse(time.RFC3339, row[0])
+		if err != nil {
+			return nil, err
+		}
+		channel, err := strconv.Atoi(row[1])
+		if err != nil {
+			return nil, err
+		}
+		temperature, err := strconv.ParseFloat(row[2], 32)
+		if err != nil {
+			return nil, err
+		}
+		humidity, err := strconv.Atoi(row[3])
+		if err != nil {
+			return nil, err
+		}
+		absent, err := strconv.ParseBool(row[4])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, Record{
+			Timestamp:   ts,
+			Channel:     channel,
+			Temperature: float32(temperature),
+			Humidity:    byte(humidity),
+			Absent:      absent,
+		})
+	}
+	return records, nil
+}
@@ -0,0 +1,70 @@
+package logfile
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// JSONWriter appends Records as newline-delimited JSON objects.
+type JSONWriter struct {
+	file *os.File
+	enc  *json.Encoder
+}
+
+// NewJSONWriter opens or creates the JSON log at path.
+func NewJSONWriter(path string) (*JSONWriter, error) {
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONWriter{file: file, enc: json.NewEncoder(file)}, nil
+}
+
+// Write implements Writer.
+func (j *JSONWriter) Write(record Record) error {
+	return j.enc.Encode(record)
+}
+
+// Close implements Writer.
+func (j *JSONWriter) Close() error {
+	return j.file.Close()
+}
+
+// JSONReader replays Records from a newline-delimited JSON log written by
+// JSONWriter.
+type JSONReader struct {
+	file *os.File
+}
+
+// NewJSONReader opens the JSON log at path for replay.
+func NewJSONReader(path string) (*JSONReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &JSONReader{file: file}, nil
+}
+
+// ReadAll implements Reader.
+func (j *JSONReader) ReadAll() ([]Record, error) {
+	defer j.file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(j.file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,75 @@
+// Package logfile provides append-only time-series log writers and readers
+// for sensor readings, in a choice of on-disk formats. Each Writer appends to
+// a single file; files are not rotated.
+package logfile
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Record is a single sensor reading with the timestamp it was taken at.
+type Record struct {
+	Timestamp   time.Time `json:"timestamp" xml:"timestamp"`
+	Channel     int       `json:"channel" xml:"channel"`
+	Temperature float32   `json:"temperature" xml:"temperature"`
+	Humidity    byte      `json:"humidity" xml:"humidity"`
+	Absent      bool      `json:"absent" xml:"absent"`
+}
+
+// Writer appends Records to a log file. Implementations must be safe to call
+// Write on repeatedly over the lifetime of the file; Close flushes and
+// releases the underlying file handle.
+type Writer interface {
+	Write(record Record) error
+	Close() error
+}
+
+// Reader replays the Records previously appended by a Writer of the same
+// format, in the order they were written.
+type Reader interface {
+	ReadAll() ([]Record, error)
+}
+
+// Format identifies one of the supported on-disk log formats.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatXML  Format = "xml"
+)
+
+// NewWriter opens path for appending (creating it if necessary) and returns a
+// Writer for the given format.
+func NewWriter(format Format, path string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVWriter(path)
+	case FormatJSON:
+		return NewJSONWriter(path)
+	case FormatXML:
+		return NewXMLWriter(path)
+	default:
+		return nil, fmt.Errorf("logfile: unknown format %q", format)
+	}
+}
+
+// NewReader opens path for reading and returns a Reader for the given format.
+func NewReader(format Format, path string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return NewCSVReader(path)
+	case FormatJSON:
+		return NewJSONReader(path)
+	case FormatXML:
+		return NewXMLReader(path)
+	default:
+		return nil, fmt.Errorf("logfile: unknown format %q", format)
+	}
+}
+
+func openAppend(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+}
@@ -0,0 +1,86 @@
+package logfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+)
+
+// XMLWriter appends Records as one <record> element per line, mirroring the
+// JSONWriter's newline-delimited layout so the file can be tailed and
+// appended to without re-writing a root element.
+type XMLWriter struct {
+	file *os.File
+}
+
+// NewXMLWriter opens or creates the XML log at path.
+func NewXMLWriter(path string) (*XMLWriter, error) {
+	file, err := openAppend(path)
+	if err != nil {
+		return nil, err
+	}
+	return &XMLWriter{file: file}, nil
+}
+
+// Write implements Writer.
+func (x *XMLWriter) Write(record Record) error {
+	data, err := xml.Marshal(xmlRecord(record))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(x.file, string(data))
+	return err
+}
+
+// Close implements Writer.
+func (x *XMLWriter) Close() error {
+	return x.file.Close()
+}
+
+// xmlRecord gives Record an explicit element name for marshalling.
+type xmlRecord Record
+
+func (r xmlRecord) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "record"}
+	return e.EncodeElement(Record(r), start)
+}
+
+// XMLReader replays Records from an XML log written by XMLWriter.
+type XMLReader struct {
+	file *os.File
+}
+
+// NewXMLReader opens the XML log at path for replay.
+func NewXMLReader(path string) (*XMLReader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &XMLReader{file: file}, nil
+}
+
+// ReadAll implements Reader.
+func (x *XMLReader) ReadAll() ([]Record, error) {
+	defer x.file.Close()
+
+	data, err := io.ReadAll(x.file)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrap the concatenated <record> elements in a synthetic root so they can
+	// be decoded as a single document.
+	wrapped := bytes.NewBufferString("<records>")
+	wrapped.Write(data)
+	wrapped.WriteString("</records>")
+
+	var doc struct {
+		Records []Record `xml:"record"`
+	}
+	if err := xml.Unmarshal(wrapped.Bytes(), &doc); err != nil {
+		return nil, err
+	}
+	return doc.Records, nil
+}
@@ -0,0 +1,137 @@
+package sensor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Scale is the temperature unit a Calibration converts readings to.
+type Scale string
+
+const (
+	ScaleCelsius    Scale = "C"
+	ScaleFahrenheit Scale = "F"
+	ScaleKelvin     Scale = "K"
+)
+
+// ChannelCalibration corrects a single channel's known drift.
+type ChannelCalibration struct {
+	TemperatureOffset float32 `json:"temperature_offset"`
+	HumidityOffset    float32 `json:"humidity_offset"`
+}
+
+// Calibration holds per-channel offsets and the output temperature scale,
+// loaded from a small JSON config file.
+type Calibration struct {
+	Scale    Scale                      `json:"scale"`
+	Channels map[int]ChannelCalibration `json:"channels"`
+}
+
+// NewCalibration returns an empty Calibration reporting in Celsius.
+func NewCalibration() *Calibration {
+	return &Calibration{Scale: ScaleCelsius, Channels: make(map[int]ChannelCalibration)}
+}
+
+// LoadCalibration reads a Calibration from path. A missing file is not an
+// error; it yields an empty, Celsius-scaled Calibration.
+func LoadCalibration(path string) (*Calibration, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewCalibration(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sensor: reading calibration: %w", err)
+	}
+
+	calib := NewCalibration()
+	if err := json.Unmarshal(data, calib); err != nil {
+		return nil, fmt.Errorf("sensor: parsing calibration: %w", err)
+	}
+	if calib.Channels == nil {
+		calib.Channels = make(map[int]ChannelCalibration)
+	}
+	return calib, nil
+}
+
+// Save writes the Calibration to path as indented JSON.
+func (c *Calibration) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("sensor: encoding calibration: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("sensor: writing calibration: %w", err)
+	}
+	return nil
+}
+
+// defaultCalibration, when set via SetCalibration, is applied to every
+// QueryDeviceSensors result package-wide, mirroring SetLogger.
+var defaultCalibration *Calibration
+
+// SetCalibration replaces the Calibration applied to every subsequent
+// QueryDeviceSensors call. Pass nil to query raw, uncalibrated readings
+// again. It is not safe to call concurrently with queries in flight.
+func SetCalibration(c *Calibration) {
+	defaultCalibration = c
+}
+
+// CurrentScale reports the Scale applied by the active Calibration, or
+// ScaleCelsius if none has been set.
+func CurrentScale() Scale {
+	if defaultCalibration == nil {
+		return ScaleCelsius
+	}
+	return defaultCalibration.Scale
+}
+
+// Apply corrects each non-absent sensor's temperature and humidity using the
+// matching channel's offsets, then converts the result to c.Scale. The raw
+// int16 temperature decoded in getSensorDataFromBytes is untouched by this
+// step, so a Calibration can never make that conversion overflow or wrap;
+// it only ever shifts the already-converted float32 value.
+func (c *Calibration) Apply(sensors []*Sensor) {
+	for _, sensor := range sensors {
+		if sensor.Absent {
+			continue
+		}
+
+		if offset, ok := c.Channels[sensor.Channel]; ok {
+			sensor.Temperature += offset.TemperatureOffset
+			sensor.Humidity = clampHumidity(int(sensor.Humidity) + int(offset.HumidityOffset))
+		}
+
+		sensor.Temperature = convertScale(sensor.Temperature, c.Scale)
+	}
+}
+
+func convertScale(celsius float32, scale Scale) float32 {
+	switch scale {
+	case ScaleFahrenheit:
+		return celsius*9/5 + 32
+	case ScaleKelvin:
+		return celsius + 273.15
+	default:
+		return celsius
+	}
+}
+
+func clampHumidity(h int) byte {
+	switch {
+	case h < 0:
+		return 0
+	case h > 100:
+		return 100
+	default:
+		return byte(h)
+	}
+}
+
+// SetTemperatureOffset computes and stores the offset needed to make channel
+// read as reference, given its current (un-offset) reading.
+func (c *Calibration) SetTemperatureOffset(channel int, current, reference float32) {
+	calib := c.Channels[channel]
+	calib.TemperatureOffset = reference - current
+	c.Channels[channel] = calib
+}
@@ -0,0 +1,105 @@
+package sensor
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestConvertScale(t *testing.T) {
+	tests := []struct {
+		name    string
+		celsius float32
+		scale   Scale
+		want    float32
+	}{
+		{"celsius passthrough", 21.5, ScaleCelsius, 21.5},
+		{"celsius is the default for an unknown scale", 21.5, Scale("bogus"), 21.5},
+		{"freezing in fahrenheit", 0, ScaleFahrenheit, 32},
+		{"boiling in fahrenheit", 100, ScaleFahrenheit, 212},
+		{"negative in fahrenheit", -5.2, ScaleFahrenheit, 22.64},
+		{"freezing in kelvin", 0, ScaleKelvin, 273.15},
+		{"negative in kelvin", -5.2, ScaleKelvin, 267.95},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertScale(tt.celsius, tt.scale)
+			if diff := got - tt.want; diff > 0.01 || diff < -0.01 {
+				t.Errorf("convertScale(%v, %v) = %v, want %v", tt.celsius, tt.scale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalibrationApply(t *testing.T) {
+	calib := NewCalibration()
+	calib.Scale = ScaleFahrenheit
+	calib.Channels[0] = ChannelCalibration{TemperatureOffset: -1, HumidityOffset: 10}
+	calib.Channels[1] = ChannelCalibration{TemperatureOffset: 0, HumidityOffset: -50}
+
+	sensors := []*Sensor{
+		{Channel: 0, Temperature: 20, Humidity: 50},
+		{Channel: 1, Temperature: 20, Humidity: 40},
+		{Channel: 2, Temperature: 20, Humidity: 40, Absent: true},
+		{Channel: 3, Temperature: 20, Humidity: 40}, // no calibration entry
+	}
+
+	calib.Apply(sensors)
+
+	if got, want := sensors[0].Temperature, convertScale(19, ScaleFahrenheit); got != want {
+		t.Errorf("channel 0 temperature = %v, want %v", got, want)
+	}
+	if got, want := sensors[0].Humidity, byte(60); got != want {
+		t.Errorf("channel 0 humidity = %v, want %v", got, want)
+	}
+
+	if got, want := sensors[1].Humidity, byte(0); got != want {
+		t.Errorf("channel 1 humidity clamped to %v, want %v", got, want)
+	}
+
+	if !sensors[2].Absent {
+		t.Errorf("channel 2 should remain untouched because it is absent")
+	}
+
+	if got, want := sensors[3].Temperature, convertScale(20, ScaleFahrenheit); got != want {
+		t.Errorf("channel 3 (no calibration entry) temperature = %v, want %v", got, want)
+	}
+}
+
+// TestGetSensorDataFromBytesRawDecode verifies the signed int16 raw
+// conversion in getSensorDataFromBytes is unaffected by calibration: Apply
+// only ever touches the already-decoded float32/byte values on a Sensor, so
+// a negative raw reading must still decode correctly whether or not a
+// Calibration is active afterwards.
+func TestGetSensorDataFromBytesRawDecode(t *testing.T) {
+	response := make([]byte, 64)
+	response[0] = 0x7b
+
+	// Channel 0: -5.2°C, 45% humidity.
+	rawTemperature := int16(-52)
+	binary.BigEndian.PutUint16(response[1:3], uint16(rawTemperature))
+	response[3] = 45
+
+	// Channel 1: absent.
+	response[4] = 0x7f
+
+	sensors := getSensorDataFromBytes(response)
+
+	if got, want := sensors[0].Temperature, float32(-5.2); got != want {
+		t.Errorf("channel 0 raw temperature = %v, want %v", got, want)
+	}
+	if got, want := sensors[0].Humidity, byte(45); got != want {
+		t.Errorf("channel 0 raw humidity = %v, want %v", got, want)
+	}
+	if !sensors[1].Absent {
+		t.Errorf("channel 1 should be reported absent")
+	}
+
+	calib := NewCalibration()
+	calib.Channels[0] = ChannelCalibration{TemperatureOffset: 0.2}
+	calib.Apply(sensors)
+
+	if got, want := sensors[0].Temperature, float32(-5.0); got < want-0.01 || got > want+0.01 {
+		t.Errorf("channel 0 temperature after offset = %v, want %v", got, want)
+	}
+}
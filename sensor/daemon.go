@@ -0,0 +1,132 @@
+package sensor
+
+import (
+	"context"
+	"math"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/SimonSpi/roomlogg-go/logfile"
+	"github.com/karalabe/hid"
+)
+
+// NewLogSink adapts a logfile.Writer to the Sink interface, so a Daemon can
+// append every reading to a CSV/JSON/XML log file.
+func NewLogSink(w logfile.Writer) Sink {
+	return SinkFunc(func(deviceInfo []hid.DeviceInfo, sensor *Sensor) {
+		record := logfile.Record{
+			Timestamp:   time.Now(),
+			Channel:     sensor.Channel,
+			Temperature: sensor.Temperature,
+			Humidity:    sensor.Humidity,
+			Absent:      sensor.Absent,
+		}
+		if err := w.Write(record); err != nil {
+			defaultLogger.Warnf("daemon: writing log record failed: %v", err)
+		}
+	})
+}
+
+// ContextWithSignals returns a context that is cancelled when the process
+// receives SIGINT or SIGTERM, along with a cancel func the caller should
+// defer to release the signal handler early (e.g. in tests).
+func ContextWithSignals(parent context.Context) (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(parent, os.Interrupt, syscall.SIGTERM)
+}
+
+// Sink receives sensor readings as they are produced by a Daemon. Implementations
+// are expected to return quickly; slow sinks will back up the daemon's per-device
+// goroutine.
+type Sink interface {
+	Handle(deviceInfo []hid.DeviceInfo, sensor *Sensor)
+}
+
+// SinkFunc adapts a plain function to the Sink interface.
+type SinkFunc func(deviceInfo []hid.DeviceInfo, sensor *Sensor)
+
+// Handle implements Sink.
+func (f SinkFunc) Handle(deviceInfo []hid.DeviceInfo, sensor *Sensor) {
+	f(deviceInfo, sensor)
+}
+
+// DaemonConfig controls how a Daemon polls its devices.
+type DaemonConfig struct {
+	// Interval is how often each device is polled. Defaults to 60s if zero.
+	Interval time.Duration
+	// Round, if non-zero, snaps reported temperatures to the nearest multiple
+	// of Round (e.g. 0.1 or 0.25).
+	Round float32
+	// Sink receives every reading produced by a poll. Required.
+	Sink Sink
+}
+
+// Daemon continuously polls one or more devices' sensors on a fixed interval
+// and forwards the results to a Sink until its context is cancelled.
+type Daemon struct {
+	devices  [][]hid.DeviceInfo
+	interval time.Duration
+	round    float32
+	sink     Sink
+}
+
+// NewDaemon builds a Daemon that polls each of the given devices. Each entry
+// in devices is passed to QueryDeviceSensors as-is, so it follows the same
+// shape as QueryAndPrintOnce's argument.
+func NewDaemon(devices [][]hid.DeviceInfo, cfg DaemonConfig) *Daemon {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = 60 * time.Second
+	}
+	return &Daemon{
+		devices:  devices,
+		interval: interval,
+		round:    cfg.Round,
+		sink:     cfg.Sink,
+	}
+}
+
+// Run starts one polling goroutine per device and blocks until ctx is
+// cancelled and every goroutine has exited cleanly.
+func (d *Daemon) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, deviceInfo := range d.devices {
+		wg.Add(1)
+		go func(deviceInfo []hid.DeviceInfo) {
+			defer wg.Done()
+			d.pollLoop(ctx, deviceInfo)
+		}(deviceInfo)
+	}
+	wg.Wait()
+}
+
+func (d *Daemon) pollLoop(ctx context.Context, deviceInfo []hid.DeviceInfo) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		sensors, err := QueryDeviceSensors(deviceInfo)
+		if err != nil {
+			defaultLogger.Warnf("daemon: polling device %v failed: %v", deviceInfo, err)
+		} else {
+			for _, sensor := range sensors {
+				if d.round > 0 && !sensor.Absent {
+					sensor.Temperature = roundTo(sensor.Temperature, d.round)
+				}
+				d.sink.Handle(deviceInfo, sensor)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func roundTo(value, step float32) float32 {
+	return float32(math.Round(float64(value/step))) * step
+}
@@ -0,0 +1,171 @@
+package sensor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/karalabe/hid"
+)
+
+// Device is one discovered roomlogg base station. A station can expose more
+// than one HID interface; Infos holds all of them in the same shape
+// QueryDeviceSensors expects; Infos[0] is the one actually opened.
+type Device struct {
+	Serial string
+	Path   string
+	Infos  []hid.DeviceInfo
+}
+
+// Query queries this device's sensors. It is equivalent to calling
+// QueryDeviceSensors(d.Infos).
+func (d Device) Query() ([]*Sensor, error) {
+	return QueryDeviceSensors(d.Infos)
+}
+
+// deviceKey is the stable identifier Discover and WatchHotplug group and diff
+// devices by: the serial number, or the USB path when a station doesn't
+// report one. It must never be "" shared across physically distinct
+// stations, so path (unique per interface) is the fallback, not Serial.
+func deviceKey(info hid.DeviceInfo) string {
+	if info.Serial != "" {
+		return info.Serial
+	}
+	return info.Path
+}
+
+func (d Device) key() string {
+	if d.Serial != "" {
+		return d.Serial
+	}
+	return d.Path
+}
+
+// Discover enumerates every roomlogg base station currently attached,
+// grouping the HID interfaces that share a deviceKey into one Device.
+func Discover() []Device {
+	infos := hid.Enumerate(VendorID, ProductID)
+
+	order := make([]string, 0)
+	grouped := make(map[string][]hid.DeviceInfo)
+	for _, info := range infos {
+		key := deviceKey(info)
+		if _, ok := grouped[key]; !ok {
+			order = append(order, key)
+		}
+		grouped[key] = append(grouped[key], info)
+	}
+
+	devices := make([]Device, 0, len(order))
+	for _, key := range order {
+		group := grouped[key]
+		devices = append(devices, Device{
+			Serial: group[0].Serial,
+			Path:   group[0].Path,
+			Infos:  group,
+		})
+	}
+	return devices
+}
+
+// QueryResult is one Device's outcome from QueryAll.
+type QueryResult struct {
+	Device  Device
+	Sensors []*Sensor
+	Err     error
+}
+
+// QueryAll queries every device from its own goroutine and waits for all of
+// them. usbLock inside QueryDeviceSensors is a single package-wide mutex, so
+// the device I/O itself still runs one device at a time; devices are not
+// read concurrently.
+func QueryAll(ctx context.Context, devices []Device) []QueryResult {
+	results := make([]QueryResult, len(devices))
+
+	var wg sync.WaitGroup
+	for i, device := range devices {
+		wg.Add(1)
+		go func(i int, device Device) {
+			defer wg.Done()
+			if err := ctx.Err(); err != nil {
+				results[i] = QueryResult{Device: device, Err: err}
+				return
+			}
+			sensors, err := device.Query()
+			results[i] = QueryResult{Device: device, Sensors: sensors, Err: err}
+		}(i, device)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// HotplugEventType distinguishes the two events WatchHotplug can emit.
+type HotplugEventType int
+
+const (
+	DeviceAdded HotplugEventType = iota
+	DeviceRemoved
+)
+
+// HotplugEvent reports that a Device appeared or disappeared between two
+// polls of WatchHotplug.
+type HotplugEvent struct {
+	Type   HotplugEventType
+	Device Device
+}
+
+// WatchHotplug polls Discover on the given interval and emits a HotplugEvent
+// each time a device (identified by its key, see deviceKey) appears or
+// disappears. The returned channel is closed once ctx is cancelled.
+func WatchHotplug(ctx context.Context, interval time.Duration) <-chan HotplugEvent {
+	events := make(chan HotplugEvent)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		known := make(map[string]Device)
+		for _, device := range Discover() {
+			known[device.key()] = device
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := make(map[string]Device)
+			for _, device := range Discover() {
+				current[device.key()] = device
+			}
+
+			for key, device := range current {
+				if _, ok := known[key]; !ok {
+					select {
+					case events <- HotplugEvent{Type: DeviceAdded, Device: device}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			for key, device := range known {
+				if _, ok := current[key]; !ok {
+					select {
+					case events <- HotplugEvent{Type: DeviceRemoved, Device: device}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			known = current
+		}
+	}()
+
+	return events
+}
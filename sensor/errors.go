@@ -0,0 +1,41 @@
+package sensor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors identifying the stage of device communication that failed.
+// Use errors.Is to test for a specific kind; the wrapped error carries the
+// underlying cause.
+var (
+	ErrDeviceOpen  = errors.New("sensor: opening device failed")
+	ErrDeviceWrite = errors.New("sensor: writing to device failed")
+	ErrDeviceRead  = errors.New("sensor: reading from device failed")
+)
+
+// wrap returns an error that reports as kind's message followed by cause,
+// satisfies errors.Is(err, kind), and unwraps to cause.
+func wrap(kind error, cause error) error {
+	return &deviceError{kind: kind, cause: cause}
+}
+
+type deviceError struct {
+	kind  error
+	cause error
+}
+
+func (e *deviceError) Error() string {
+	if e.cause == nil {
+		return e.kind.Error()
+	}
+	return fmt.Sprintf("%s: %v", e.kind, e.cause)
+}
+
+func (e *deviceError) Is(target error) bool {
+	return e.kind == target
+}
+
+func (e *deviceError) Unwrap() error {
+	return e.cause
+}
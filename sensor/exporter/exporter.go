@@ -0,0 +1,126 @@
+// Package exporter exposes roomlogg sensor readings as Prometheus metrics
+// over HTTP.
+package exporter
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/SimonSpi/roomlogg-go/sensor"
+	"github.com/karalabe/hid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Exporter scrapes sensor readings on demand and serves them as Prometheus
+// metrics. Reads are cached for CacheTTL so concurrent scrapes don't thrash
+// usbLock.
+type Exporter struct {
+	devices  [][]hid.DeviceInfo
+	cacheTTL time.Duration
+	gatherer prometheus.Gatherer
+
+	temperature *prometheus.GaugeVec
+	humidity    *prometheus.GaugeVec
+
+	mu         sync.Mutex
+	lastScrape time.Time
+}
+
+// Config controls an Exporter's caching behaviour.
+type Config struct {
+	// CacheTTL is how long a scrape's readings are reused for subsequent
+	// scrapes before the devices are queried again. Defaults to 10s if zero.
+	CacheTTL time.Duration
+}
+
+// New creates an Exporter for the given devices and registers its metrics
+// with reg. The temperature gauge's name and unit follow the Scale of
+// whatever Calibration is active via sensor.SetCalibration at call time.
+func New(devices [][]hid.DeviceInfo, reg *prometheus.Registry, cfg Config) *Exporter {
+	cacheTTL := cfg.CacheTTL
+	if cacheTTL <= 0 {
+		cacheTTL = 10 * time.Second
+	}
+
+	unit, unitHelp := scaleUnit(sensor.CurrentScale())
+
+	e := &Exporter{
+		devices:  devices,
+		cacheTTL: cacheTTL,
+		gatherer: reg,
+		temperature: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "roomlogg",
+			Name:      "temperature_" + unit,
+			Help:      "Last reported temperature in " + unitHelp + ".",
+		}, []string{"channel", "serial"}),
+		humidity: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "roomlogg",
+			Name:      "humidity_percent",
+			Help:      "Last reported relative humidity percentage.",
+		}, []string{"channel", "serial"}),
+	}
+
+	reg.MustRegister(e.temperature, e.humidity)
+	return e
+}
+
+// scaleUnit maps a sensor.Scale to the Prometheus metric name suffix and a
+// human-readable unit for the gauge's help text.
+func scaleUnit(scale sensor.Scale) (name, help string) {
+	switch scale {
+	case sensor.ScaleFahrenheit:
+		return "fahrenheit", "degrees Fahrenheit"
+	case sensor.ScaleKelvin:
+		return "kelvin", "Kelvin"
+	default:
+		return "celsius", "degrees Celsius"
+	}
+}
+
+// Collect refreshes the cached metrics if CacheTTL has elapsed since the last
+// scrape.
+func (e *Exporter) Collect() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if time.Since(e.lastScrape) < e.cacheTTL {
+		return
+	}
+	e.lastScrape = time.Now()
+
+	// Reset before repopulating so a channel that goes Absent, or a device
+	// that stops responding, drops out of the exposition instead of
+	// reporting its last value forever.
+	e.temperature.Reset()
+	e.humidity.Reset()
+
+	for _, deviceInfo := range e.devices {
+		serial := deviceInfo[0].Serial
+		sensors, err := sensor.QueryDeviceSensors(deviceInfo)
+		if err != nil {
+			continue
+		}
+		for _, s := range sensors {
+			if s.Absent {
+				continue
+			}
+			channel := strconv.Itoa(s.Channel)
+			e.temperature.WithLabelValues(channel, serial).Set(float64(s.Temperature))
+			e.humidity.WithLabelValues(channel, serial).Set(float64(s.Humidity))
+		}
+	}
+}
+
+// Handler returns an http.Handler that refreshes the cache and serves the
+// metrics registered with the registry passed to New, in the Prometheus
+// exposition format.
+func (e *Exporter) Handler() http.Handler {
+	inner := promhttp.HandlerFor(e.gatherer, promhttp.HandlerOpts{})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		e.Collect()
+		inner.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,59 @@
+package sensor
+
+import (
+	"log"
+	"os"
+)
+
+// Logger lets library consumers route the package's log output into their
+// own logging stack (e.g. zap or zerolog) instead of the stdlib default.
+type Logger interface {
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warnf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+}
+
+// stdLogger is the default Logger, backed by the standard library's log
+// package. Debug messages are suppressed unless Verbose is set.
+type stdLogger struct {
+	Verbose bool
+	logger  *log.Logger
+}
+
+// NewStdLogger returns a Logger that writes to stderr via the stdlib log
+// package. When verbose is false, Debugf calls are discarded.
+func NewStdLogger(verbose bool) Logger {
+	return &stdLogger{
+		Verbose: verbose,
+		logger:  log.New(os.Stderr, "", log.LstdFlags),
+	}
+}
+
+func (s *stdLogger) Debugf(format string, args ...interface{}) {
+	if s.Verbose {
+		s.logger.Printf("DEBUG "+format, args...)
+	}
+}
+
+func (s *stdLogger) Infof(format string, args ...interface{}) {
+	s.logger.Printf("INFO "+format, args...)
+}
+
+func (s *stdLogger) Warnf(format string, args ...interface{}) {
+	s.logger.Printf("WARN "+format, args...)
+}
+
+func (s *stdLogger) Errorf(format string, args ...interface{}) {
+	s.logger.Printf("ERROR "+format, args...)
+}
+
+// defaultLogger is used by package functions unless overridden with
+// SetLogger.
+var defaultLogger Logger = NewStdLogger(false)
+
+// SetLogger replaces the package's default Logger. It is not safe to call
+// concurrently with queries in flight.
+func SetLogger(l Logger) {
+	defaultLogger = l
+}
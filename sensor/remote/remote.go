@@ -0,0 +1,189 @@
+// Package remote batches sensor readings and ships them to an HTTP
+// collector, spooling to disk across outages so nothing is lost between a
+// garage Pi and a cloud dashboard.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/SimonSpi/roomlogg-go/sensor"
+)
+
+// Reading is one sensor sample queued for delivery to the remote collector.
+type Reading struct {
+	Timestamp    time.Time `json:"timestamp"`
+	DeviceSerial string    `json:"device_serial"`
+	Channel      int       `json:"channel"`
+	Temperature  float32   `json:"temperature"`
+	Humidity     byte      `json:"humidity"`
+	Absent       bool      `json:"absent"`
+}
+
+// Config controls a Client's delivery behaviour.
+type Config struct {
+	// Endpoint is the URL readings are POSTed to as a JSON array.
+	Endpoint string
+	// SpoolPath is where undelivered readings are persisted across restarts.
+	// Required.
+	SpoolPath string
+	// HMACSecret, if set, adds an X-Roomlogg-Signature header containing the
+	// hex-encoded HMAC-SHA256 of the request body.
+	HMACSecret []byte
+	// MaxRetries is how many immediate attempts Flush makes before spooling
+	// and giving up for this call. Defaults to 3.
+	MaxRetries int
+	// RetryBackoff is the initial delay between retries, doubled each
+	// attempt. Defaults to 1s.
+	RetryBackoff time.Duration
+	// HTTPClient is used to deliver readings. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Logger receives delivery diagnostics. Defaults to a non-verbose
+	// sensor.NewStdLogger.
+	Logger sensor.Logger
+}
+
+// Client batches Readings in memory and periodically flushes them to the
+// configured remote endpoint, spooling to disk when it can't be reached.
+type Client struct {
+	cfg Config
+
+	mu      sync.Mutex
+	pending []Reading
+}
+
+// NewClient creates a Client. SpoolPath must be set.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.SpoolPath == "" {
+		return nil, fmt.Errorf("remote: SpoolPath is required")
+	}
+	if cfg.MaxRetries <= 0 {
+		cfg.MaxRetries = 3
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = time.Second
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = sensor.NewStdLogger(false)
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// Enqueue adds a reading to the in-memory batch. It will be sent on the next
+// Flush.
+func (c *Client) Enqueue(reading Reading) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = append(c.pending, reading)
+}
+
+// Run periodically calls Flush until ctx is cancelled.
+func (c *Client) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Flush(ctx); err != nil {
+				c.cfg.Logger.Warnf("remote: flush failed: %v", err)
+			}
+		}
+	}
+}
+
+// Flush sends every pending reading plus anything left over in the on-disk
+// spool. Readings that still can't be delivered after MaxRetries are written
+// back to the spool for the next Flush to pick up.
+func (c *Client) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	batch := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	spooled, err := readSpool(c.cfg.SpoolPath)
+	if err != nil {
+		return fmt.Errorf("remote: reading spool: %w", err)
+	}
+	batch = append(spooled, batch...)
+	if len(batch) == 0 {
+		return nil
+	}
+
+	if err := c.send(ctx, batch); err != nil {
+		c.cfg.Logger.Warnf("remote: delivery failed, spooling %d readings: %v", len(batch), err)
+		if spoolErr := writeSpool(c.cfg.SpoolPath, batch); spoolErr != nil {
+			return fmt.Errorf("remote: delivery failed (%v) and spooling failed: %w", err, spoolErr)
+		}
+		return err
+	}
+
+	return clearSpool(c.cfg.SpoolPath)
+}
+
+func (c *Client) send(ctx context.Context, batch []Reading) error {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("remote: marshalling batch: %w", err)
+	}
+
+	backoff := c.cfg.RetryBackoff
+	var lastErr error
+	for attempt := 0; attempt < c.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		if lastErr = c.post(ctx, body); lastErr == nil {
+			return nil
+		}
+		c.cfg.Logger.Debugf("remote: attempt %d failed: %v", attempt+1, lastErr)
+	}
+	return lastErr
+}
+
+func (c *Client) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(c.cfg.HMACSecret) > 0 {
+		req.Header.Set("X-Roomlogg-Signature", sign(c.cfg.HMACSecret, body))
+	}
+
+	resp, err := c.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("remote: collector returned %s", resp.Status)
+	}
+	return nil
+}
+
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package remote
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+)
+
+// readSpool loads any readings left over from a previous failed Flush. A
+// missing spool file is not an error.
+func readSpool(path string) ([]Reading, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var readings []Reading
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var reading Reading
+		if err := json.Unmarshal(line, &reading); err != nil {
+			return nil, err
+		}
+		readings = append(readings, reading)
+	}
+	return readings, scanner.Err()
+}
+
+// writeSpool persists readings as newline-delimited JSON, replacing whatever
+// was previously spooled.
+func writeSpool(path string, readings []Reading) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	enc := json.NewEncoder(file)
+	for _, reading := range readings {
+		if err := enc.Encode(reading); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// clearSpool removes the spool file once its contents have been delivered.
+func clearSpool(path string) error {
+	err := os.Remove(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
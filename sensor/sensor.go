@@ -2,10 +2,11 @@ package sensor
 
 import (
 	"encoding/binary"
-	"log"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/SimonSpi/roomlogg-go/logfile"
 	"github.com/karalabe/hid"
 )
 
@@ -16,58 +17,100 @@ type Sensor struct {
 	Absent      bool
 }
 
+// VendorID and ProductID identify the roomlogg base station's USB HID
+// interface for hid.Enumerate.
+const (
+	VendorID  = 0x0483
+	ProductID = 0x5750
+)
+
 var usbLock sync.Mutex
 
 // CheckDeviceWithoutQuery tests whether the given device can be opened without reading sensor data
 func CheckDeviceWithoutQuery(deviceInfo []hid.DeviceInfo) bool {
-	valid := true
 	usbLock.Lock()
+	defer usbLock.Unlock()
+
 	device, err := deviceInfo[0].Open()
 	if err != nil {
-		valid = false
+		return false
+	}
+	if err := closeDevice(device); err != nil {
+		defaultLogger.Warnf("closing device after check failed: %v", err)
 	}
-	closeDevice(device)
-	usbLock.Unlock()
-	return valid
+	return true
 }
 
-// QueryAndPrintOnce queries the device's sensors once and prints them out
-func QueryAndPrintOnce(deviceInfo []hid.DeviceInfo) {
+// QueryAndPrintOnce queries the device's sensors once and prints them out.
+func QueryAndPrintOnce(deviceInfo []hid.DeviceInfo) error {
 	sensors, err := QueryDeviceSensors(deviceInfo)
 	if err != nil {
-		log.Fatal("Querying sensor failed")
+		return err
+	}
+	unit := "°C"
+	switch CurrentScale() {
+	case ScaleFahrenheit:
+		unit = "°F"
+	case ScaleKelvin:
+		unit = "K"
 	}
 	for i := range sensors {
 		sensor := sensors[i]
 		if sensor.Absent {
-			log.Printf("No sensor on Channel %d", sensor.Channel)
+			defaultLogger.Infof("No sensor on Channel %d", sensor.Channel)
 		} else {
-			log.Printf("Channel %d: %.1f°C  %d%%\n", sensor.Channel, sensor.Temperature, sensor.Humidity)
+			defaultLogger.Infof("Channel %d: %.1f%s  %d%%", sensor.Channel, sensor.Temperature, unit, sensor.Humidity)
 		}
 	}
+	return nil
+}
+
+// QueryAndLog queries the device's sensors once and appends the results to w.
+func QueryAndLog(deviceInfo []hid.DeviceInfo, w logfile.Writer) error {
+	sensors, err := QueryDeviceSensors(deviceInfo)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, sensor := range sensors {
+		record := logfile.Record{
+			Timestamp:   now,
+			Channel:     sensor.Channel,
+			Temperature: sensor.Temperature,
+			Humidity:    sensor.Humidity,
+			Absent:      sensor.Absent,
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // QueryDeviceSensors queries the device's sensors and returns the sensor data
 func QueryDeviceSensors(deviceInfo []hid.DeviceInfo) ([]*Sensor, error) {
-	log.Printf("Opening device %v...\n", deviceInfo)
+	defaultLogger.Debugf("Opening device %v...", deviceInfo)
 
 	usbLock.Lock()
 	device, err := deviceInfo[0].Open()
 	if err != nil {
-		log.Printf("Opening device failed: %v\n", err)
-		return nil, err
+		usbLock.Unlock()
+		return nil, wrap(ErrDeviceOpen, err)
 	}
 	defer usbLock.Unlock()
-	defer closeDevice(device)
+	defer func() {
+		if err := closeDevice(device); err != nil {
+			defaultLogger.Warnf("closing device failed: %v", err)
+		}
+	}()
 
 	requestBytes := getTempRequestBytes()
-	log.Printf("Writing to device: %x", requestBytes)
+	defaultLogger.Debugf("Writing to device: %x", requestBytes)
 	writtenBytes, err := device.Write(requestBytes)
 	if err != nil {
-		log.Printf("Writing to device failed: %v\n", err)
-		return nil, err
+		return nil, wrap(ErrDeviceWrite, err)
 	}
-	log.Printf("Wrote %v bytes", writtenBytes)
+	defaultLogger.Debugf("Wrote %v bytes", writtenBytes)
 
 	time.Sleep(500 * time.Millisecond)
 
@@ -75,12 +118,14 @@ func QueryDeviceSensors(deviceInfo []hid.DeviceInfo) ([]*Sensor, error) {
 
 	readBytes, err := device.Read(response)
 	if err != nil {
-		log.Printf("Reading from device failed: %v\n", err)
-		return nil, err
+		return nil, wrap(ErrDeviceRead, err)
 	}
-	log.Printf("Read result (%v bytes): %x", readBytes, response)
+	defaultLogger.Debugf("Read result (%v bytes): %x", readBytes, response)
 
 	sensorData := getSensorDataFromBytes(response)
+	if defaultCalibration != nil {
+		defaultCalibration.Apply(sensorData)
+	}
 	return sensorData, nil
 }
 
@@ -116,10 +161,10 @@ func getTempRequestBytes() []byte {
 	return b
 }
 
-func closeDevice(device *hid.Device) {
-	log.Println("Closing device...")
-	err := device.Close()
-	if err != nil {
-		log.Fatalln("Closing device failed")
+func closeDevice(device *hid.Device) error {
+	defaultLogger.Debugf("Closing device...")
+	if err := device.Close(); err != nil {
+		return fmt.Errorf("sensor: closing device failed: %w", err)
 	}
+	return nil
 }